@@ -8,6 +8,11 @@ type ENTITY_NAME struct {
 	// Add domain fields here
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// Uncomment for a tenant-owned entity, and implement entity.Tenanted's
+	// two methods below. Leave both out for system-level entities that
+	// have no tenant of their own.
+	// TenantID string
 }
 
 // Optional constructor
@@ -18,4 +23,12 @@ func NewENTITY_NAME(id string /* add parameters */) ENTITY_NAME {
 	}
 }
 
+// GetTenantID and WithTenantID implement entity.Tenanted. Uncomment along
+// with the TenantID field above to opt ENTITY_NAME into tenant scoping.
+// func (e ENTITY_NAME) GetTenantID() string { return e.TenantID }
+// func (e ENTITY_NAME) WithTenantID(id string) any {
+// 	e.TenantID = id
+// 	return e
+// }
+
 // REMEMBER: NO business logic, NO validation, NO database annotations
@@ -2,55 +2,27 @@
 package persistence
 
 import (
-	"context"
-
 	"github.com/bhlabz/maxsatt-api/internal/domain/entity"
 	"github.com/bhlabz/maxsatt-api/internal/integration/adapter"
+	"github.com/bhlabz/maxsatt-api/internal/integration/persistence/ent"
 	"github.com/bhlabz/maxsatt-api/internal/integration/persistence/model"
-	"github.com/bhlabz/maxsatt-api/pkg/errs"
-	"github.com/bhlabz/maxsatt-api/pkg/logger"
-	"gorm.io/gorm"
+	"github.com/bhlabz/maxsatt-api/pkg/event"
+	"github.com/bhlabz/maxsatt-api/pkg/tenant"
 )
 
-type ENTITY_NAMERepository struct {
-	db *gorm.DB
-}
-
-func NewENTITY_NAMERepository(db *gorm.DB) adapter.ENTITY_NAMERepository {
-	return &ENTITY_NAMERepository{db: db}
-}
-
-// Implements usecase.SaveENTITY_NAME
-func (r *ENTITY_NAMERepository) Save(ctx context.Context, entityObj entity.ENTITY_NAME) (*entity.ENTITY_NAME, error) {
-	logger.Debug(ctx, "Started", entityObj)
-
-	modelObj := model.NewENTITY_NAMEModel(entityObj)
-	if err := r.db.Create(modelObj).Error; err != nil {
-		return nil, errs.DatabaseError(err, "PREFIX-02500")
-	}
-
-	result := modelObj.ToEntity()
-	logger.Debug(ctx, "Finished", result)
-	return &result, nil
-}
-
-// Implements usecase.FindENTITY_NAME
-func (r *ENTITY_NAMERepository) FindById(ctx context.Context, id string) (*entity.ENTITY_NAME, error) {
-	logger.Debug(ctx, "Started", id)
-
-	var modelObj model.ENTITY_NAME
-	err := r.db.Where("id = ?", id).First(&modelObj).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, errs.NotFoundError("ENTITY_NAME not found", "PREFIX-01404")
-	}
-	if err != nil {
-		return nil, errs.DatabaseError(err, "PREFIX-02500")
-	}
-
-	result := modelObj.ToEntity()
-	logger.Debug(ctx, "Finished", result)
-	return &result, nil
+// NewENTITY_NAMERepository wires the Store[entity.ENTITY_NAME]/TxManager pair
+// for whichever backend f is configured for (gorm/ent/memory). entClient/
+// entTransactor are only consulted when f is configured for the "ent"
+// driver; pass nil for both until entity.ENTITY_NAME has a generated ent
+// schema. Wrap the returned Store in a dedicated struct here if it needs
+// finders beyond the generic Get/GetByID/List/Search/Save/Update/Delete
+// surface. Swap tenant.Off for tenant.Strict/tenant.Optional once
+// entity.ENTITY_NAME carries a TenantID.
+func NewENTITY_NAMERepository(
+	f *Factory,
+	publisher event.Publisher,
+	entClient ent.EntClient[entity.ENTITY_NAME],
+	entTransactor ent.Transactor,
+) (adapter.ENTITY_NAMERepository, TxManager, error) {
+	return NewStore(f, model.NewENTITY_NAMEModel, "PREFIX-01404", "PREFIX-02500", publisher, tenant.Off, entClient, entTransactor)
 }
-
-// Add other usecase interface implementations
-// FindByField, ListByPageAndLimit, Update, Delete, etc.
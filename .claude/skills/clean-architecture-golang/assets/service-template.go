@@ -7,6 +7,7 @@ import (
 	"github.com/bhlabz/maxsatt-api/internal/application/adapter"
 	"github.com/bhlabz/maxsatt-api/internal/application/usecase"
 	"github.com/bhlabz/maxsatt-api/internal/domain/entity"
+	"github.com/bhlabz/maxsatt-api/internal/integration/persistence"
 	"github.com/bhlabz/maxsatt-api/pkg/logger"
 )
 
@@ -15,15 +16,18 @@ type ACTION_ENTITYService struct {
 	findENTITY usecase.FindENTITY
 	saveENTITY usecase.SaveENTITY
 	// Add adapter dependencies for other services if needed
+	tm persistence.TxManager
 }
 
 func NewACTION_ENTITYService(
 	findENTITY usecase.FindENTITY,
 	saveENTITY usecase.SaveENTITY,
+	tm persistence.TxManager,
 ) adapter.ACTION_ENTITYService {
 	return &ACTION_ENTITYService{
 		findENTITY: findENTITY,
 		saveENTITY: saveENTITY,
+		tm:         tm,
 	}
 }
 
@@ -33,15 +37,24 @@ func (s *ACTION_ENTITYService) ACTION(ctx context.Context, entityObj entity.ENTI
 	// 1. Business validation
 	// Check if exists, validate business rules, etc.
 
-	// 2. Create main operation
-	result, err := s.saveENTITY.Save(ctx, entityObj)
+	// 2. Create main operation + orchestrate dependent operations atomically
+	var result *entity.ENTITY
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		saved, err := s.saveENTITY.Save(ctx, entityObj)
+		if err != nil {
+			return err
+		}
+		result = saved
+
+		// Orchestrate dependent operations here; they run in the same
+		// transaction as the save above and roll back together with it.
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// 3. Orchestrate dependent operations if needed
-	// Call other services, send events, etc.
-
 	logger.Info(ctx, "Finished", result)
 	return result, nil
 }
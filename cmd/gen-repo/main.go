@@ -0,0 +1,81 @@
+// Command gen-repo scaffolds a new entity's persistence layer: the GORM
+// model, the entity-specific repository composing persistence.Repository,
+// the adapter port it implements, and the wire provider set to plug it
+// into DI. Run it from the module root:
+//
+//	go run ./cmd/gen-repo -entity PaymentMethod -prefix PAYM
+package main
+
+import (
+	"embed"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+type entityData struct {
+	Name   string
+	Prefix string
+}
+
+func (d entityData) Lower() string {
+	return strings.ToLower(d.Name[:1]) + d.Name[1:]
+}
+
+var outputs = map[string]string{
+	"model.go.tmpl":        "internal/integration/persistence/model/%s.go",
+	"repository.go.tmpl":   "internal/integration/persistence/%s.go",
+	"adapter.go.tmpl":      "internal/integration/adapter/%s.go",
+	"searchfilter.go.tmpl": "internal/application/adapter/%s_filter.go",
+	"wire.go.tmpl":         "internal/integration/persistence/%s_wire.go",
+}
+
+func main() {
+	entity := flag.String("entity", "", "PascalCase entity name, e.g. PaymentMethod")
+	prefix := flag.String("prefix", "", "short error-code prefix, e.g. PAYM")
+	flag.Parse()
+
+	if *entity == "" || *prefix == "" {
+		fmt.Fprintln(os.Stderr, "usage: gen-repo -entity PaymentMethod -prefix PAYM")
+		os.Exit(1)
+	}
+
+	data := entityData{Name: *entity, Prefix: strings.ToUpper(*prefix)}
+
+	for tmplName, outPattern := range outputs {
+		if err := render(tmplName, fmt.Sprintf(outPattern, data.Lower()), data); err != nil {
+			log.Fatalf("gen-repo: %s: %v", tmplName, err)
+		}
+	}
+}
+
+func render(tmplName, outPath string, data entityData) error {
+	tmpl, err := template.ParseFS(templates, "templates/"+tmplName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := tmpl.Execute(f, data); err != nil {
+		return err
+	}
+
+	fmt.Println("wrote", outPath)
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package adapter declares the ports the application layer exposes to its
+// callers (services, HTTP handlers) and the ports it expects from
+// integration (repositories) — the ENTITY_NAMESearchFilter structs and the
+// pagination types every Search method shares.
+package adapter
+
+// Pagination describes the page window a caller wants back. Page is
+// 1-indexed; a zero Page is treated as the first page.
+type Pagination struct {
+	Page    uint
+	PerPage uint
+}
+
+// SortSpec describes a single ORDER BY clause. Field must name a column the
+// repository recognizes, not arbitrary client input.
+type SortSpec struct {
+	Field     string
+	Ascending bool
+}
+
+// PaginatedResult is the envelope every repository Search method returns.
+type PaginatedResult[T any] struct {
+	Items   []T
+	Total   int64
+	Page    uint
+	PerPage uint
+	HasNext bool
+}
+
+// CursorPage is the envelope SearchCursor returns. Unlike PaginatedResult it
+// carries no Total/Page — keyset pagination never counts or skips rows, it
+// only knows the cursor to resume from. NextCursor is "" when HasMore is
+// false.
+type CursorPage[T any] struct {
+	Items      []T
+	NextCursor string
+	HasMore    bool
+}
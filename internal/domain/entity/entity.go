@@ -0,0 +1,16 @@
+package entity
+
+// Domain is implemented by every domain entity. It is the minimal surface
+// the generic persistence layer needs to operate on an entity without
+// knowing its concrete shape.
+type Domain interface {
+	GetID() string
+}
+
+// Tenanted is implemented by entities that carry a tenant of their own.
+// Entities that don't implement it (system-level entities) are never
+// scoped by tenant, regardless of a repository's tenant.Enforcement mode.
+type Tenanted interface {
+	GetTenantID() string
+	WithTenantID(id string) any
+}
@@ -0,0 +1,10 @@
+// Package config holds the statically-typed configuration blocks the
+// integration layer binds from the application's config file/env at boot.
+package config
+
+// Persistence selects and configures the persistence.Factory's backend.
+type Persistence struct {
+	// Driver is one of "postgres", "mysql", "sqlite", "ent", or "memory".
+	Driver string
+	DSN    string
+}
@@ -0,0 +1,46 @@
+// Package middleware hosts cross-cutting net/http handler wrappers shared
+// across the HTTP layer.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/GabiHert/finance-tracker-e2e/pkg/tenant"
+)
+
+// TenantHeader is the fallback header Tenant reads the tenant id from when
+// extractClaims finds none.
+const TenantHeader = "X-Tenant-ID"
+
+// ClaimsExtractor pulls a tenant id out of an authenticated request, e.g.
+// from JWT claims an earlier auth middleware stashed on the request context.
+// It returns "" when no claims are present or none carry a tenant.
+type ClaimsExtractor func(r *http.Request) string
+
+// Tenant resolves the active tenant for each request via extractClaims and
+// populates it on the request context via tenant.WithTenant, so services
+// and repositories downstream need no per-call plumbing. Requests that
+// resolve no tenant at all are passed through unchanged; a repository
+// configured with tenant.Strict is what actually rejects them.
+//
+// allowHeaderFallback, when true, falls back to the TenantHeader header for
+// requests where extractClaims returns "". Only set this behind a route
+// where that header is guaranteed to come from a trusted upstream (e.g. a
+// gateway that sets/overwrites it after its own auth, never forwarding a
+// caller-supplied value) - nothing downstream re-checks it, so on a route a
+// caller can reach directly it lets them self-assign any tenant id tenant.
+// Strict would otherwise enforce.
+func Tenant(extractClaims ClaimsExtractor, allowHeaderFallback bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := extractClaims(r)
+			if id == "" && allowHeaderFallback {
+				id = r.Header.Get(TenantHeader)
+			}
+			if id != "" {
+				r = r.WithContext(tenant.WithTenant(r.Context(), id))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,393 @@
+// Package contract is the conformance suite every persistence.Store[E]
+// backend must pass: Save/GetByID round-trip, NotFound mapping,
+// transaction rollback semantics, and pagination correctness. Each backend
+// gets a *_test.go that calls Run with its own Suite.
+package contract
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GabiHert/finance-tracker-e2e/internal/application/adapter"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/errs"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/tenant"
+)
+
+// Fixture is the minimal entity every backend's conformance suite is run
+// against.
+type Fixture struct {
+	ID   string
+	Name string
+}
+
+// GetID implements entity.Domain.
+func (f Fixture) GetID() string { return f.ID }
+
+// FixtureFilter exercises Search's reflective filter support against a
+// concrete, populated filter rather than the nil every other test passes.
+type FixtureFilter struct {
+	Name *string `filter:"Name"`
+}
+
+// TenantFixture is the tenant-scoped entity the conformance suite runs its
+// tenant-scoping cases against. Unlike Fixture, it implements entity.Tenanted
+// so backends actually exercise their tenantScope path instead of skipping
+// it outright.
+type TenantFixture struct {
+	ID       string
+	TenantID string
+	Name     string
+}
+
+// GetID implements entity.Domain.
+func (f TenantFixture) GetID() string { return f.ID }
+
+// GetTenantID implements entity.Tenanted.
+func (f TenantFixture) GetTenantID() string { return f.TenantID }
+
+// WithTenantID implements entity.Tenanted.
+func (f TenantFixture) WithTenantID(id string) any {
+	f.TenantID = id
+	return f
+}
+
+// Suite is what a backend provides to plug into the conformance tests.
+type Suite struct {
+	// NewStore returns a fresh, empty Store[Fixture] backed by this backend.
+	NewStore func(t *testing.T) persistence.Store[Fixture]
+	// NewTxManager returns a TxManager sharing the same storage as the
+	// Store NewStore just returned, or nil if the backend's *_test.go
+	// wants to skip the rollback case (e.g. it isn't wired up yet).
+	NewTxManager func(t *testing.T, store persistence.Store[Fixture]) persistence.TxManager
+	// NewTenantStore returns a fresh, empty Store[TenantFixture] backed by
+	// this backend and configured with the given tenant.Enforcement, or nil
+	// if the backend's *_test.go wants to skip the tenant-scoping cases
+	// (e.g. it isn't wired up yet).
+	NewTenantStore func(t *testing.T, enforcement tenant.Enforcement) persistence.Store[TenantFixture]
+}
+
+// Run exercises Suite against the shared behavioral contract.
+func Run(t *testing.T, s Suite) {
+	t.Run("SaveAndGetByIDRoundTrip", func(t *testing.T) { testSaveAndGetByIDRoundTrip(t, s) })
+	t.Run("GetByIDNotFound", func(t *testing.T) { testGetByIDNotFound(t, s) })
+	t.Run("SearchPagination", func(t *testing.T) { testSearchPagination(t, s) })
+	t.Run("SearchFilter", func(t *testing.T) { testSearchFilter(t, s) })
+	t.Run("SearchInvalidSort", func(t *testing.T) { testSearchInvalidSort(t, s) })
+	t.Run("IterateAndSearchCursor", func(t *testing.T) { testIterateAndSearchCursor(t, s) })
+	if s.NewTxManager != nil {
+		t.Run("TransactionRollback", func(t *testing.T) { testTransactionRollback(t, s) })
+	}
+	if s.NewTenantStore != nil {
+		t.Run("TenantScopingOff", func(t *testing.T) { testTenantScopingOff(t, s) })
+		t.Run("TenantScopingStrict", func(t *testing.T) { testTenantScopingStrict(t, s) })
+		t.Run("TenantScopingOptional", func(t *testing.T) { testTenantScopingOptional(t, s) })
+	}
+}
+
+func testSaveAndGetByIDRoundTrip(t *testing.T, s Suite) {
+	ctx := context.Background()
+	store := s.NewStore(t)
+
+	saved, err := store.Save(ctx, Fixture{ID: "fixture-1", Name: "first"})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := store.GetByID(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Name != "first" {
+		t.Fatalf("GetByID returned %+v, want Name=first", got)
+	}
+}
+
+func testGetByIDNotFound(t *testing.T, s Suite) {
+	ctx := context.Background()
+	store := s.NewStore(t)
+
+	_, err := store.GetByID(ctx, "missing")
+	if err == nil {
+		t.Fatal("GetByID of a missing id: want error, got nil")
+	}
+
+	var notFound *errs.NotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("GetByID error = %v (%T), want *errs.NotFound", err, err)
+	}
+}
+
+func testSearchPagination(t *testing.T, s Suite) {
+	ctx := context.Background()
+	store := s.NewStore(t)
+
+	for i, name := range []string{"a", "b", "c"} {
+		if _, err := store.Save(ctx, Fixture{ID: fmtID(i), Name: name}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	page, err := store.Search(ctx, nil, adapter.Pagination{Page: 1, PerPage: 2}, nil)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if page.Total != 3 {
+		t.Fatalf("Search Total = %d, want 3", page.Total)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("Search page 1 len(Items) = %d, want 2", len(page.Items))
+	}
+	if !page.HasNext {
+		t.Fatal("Search page 1 HasNext = false, want true")
+	}
+
+	rest, err := store.Search(ctx, nil, adapter.Pagination{Page: 2, PerPage: 2}, nil)
+	if err != nil {
+		t.Fatalf("Search page 2: %v", err)
+	}
+	if len(rest.Items) != 1 {
+		t.Fatalf("Search page 2 len(Items) = %d, want 1", len(rest.Items))
+	}
+	if rest.HasNext {
+		t.Fatal("Search page 2 HasNext = true, want false")
+	}
+}
+
+// testSearchFilter exercises Search with a populated SearchFilter rather
+// than the nil every other test passes, so the reflective `filter:"column"`
+// matching this request adds is actually covered, not just its pagination
+// plumbing.
+func testSearchFilter(t *testing.T, s Suite) {
+	ctx := context.Background()
+	store := s.NewStore(t)
+
+	for i, name := range []string{"apple", "banana", "apple"} {
+		if _, err := store.Save(ctx, Fixture{ID: fmtID(i), Name: name}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	name := "apple"
+	matches, err := store.Search(ctx, &FixtureFilter{Name: &name}, adapter.Pagination{Page: 1, PerPage: 10}, nil)
+	if err != nil {
+		t.Fatalf("Search with filter: %v", err)
+	}
+	if matches.Total != 2 {
+		t.Fatalf("Search with filter Total = %d, want 2", matches.Total)
+	}
+	for _, item := range matches.Items {
+		if item.Name != "apple" {
+			t.Fatalf("Search with filter returned %+v, want Name=apple", item)
+		}
+	}
+
+	missing := "missing"
+	none, err := store.Search(ctx, &FixtureFilter{Name: &missing}, adapter.Pagination{Page: 1, PerPage: 10}, nil)
+	if err != nil {
+		t.Fatalf("Search with a non-matching filter: %v", err)
+	}
+	if none.Total != 0 {
+		t.Fatalf("Search with a non-matching filter Total = %d, want 0", none.Total)
+	}
+}
+
+// testSearchInvalidSort exercises Search with a SortSpec.Field neither
+// backend can possibly recognize, so a typo'd sort field fails the same way
+// against every backend instead of being silently skipped by one and
+// hard-rejected by another.
+func testSearchInvalidSort(t *testing.T, s Suite) {
+	ctx := context.Background()
+	store := s.NewStore(t)
+
+	if _, err := store.Save(ctx, Fixture{ID: "a", Name: "a"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	_, err := store.Search(ctx, nil, adapter.Pagination{Page: 1, PerPage: 10}, []adapter.SortSpec{{Field: "not_a_real_column"}})
+	if err == nil {
+		t.Fatal("Search with an unrecognized sort field: want error, got nil")
+	}
+
+	var invalidSort *errs.InvalidSort
+	if !errors.As(err, &invalidSort) {
+		t.Fatalf("Search with an unrecognized sort field error = %v (%T), want *errs.InvalidSort", err, err)
+	}
+}
+
+func testIterateAndSearchCursor(t *testing.T, s Suite) {
+	ctx := context.Background()
+	store := s.NewStore(t)
+
+	for i, name := range []string{"a", "b", "c"} {
+		if _, err := store.Save(ctx, Fixture{ID: fmtID(i), Name: name}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	var visited []string
+	err := store.Iterate(ctx, nil, 2, func(f Fixture) error {
+		visited = append(visited, f.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Iterate: %v", err)
+	}
+	if len(visited) != 3 {
+		t.Fatalf("Iterate visited %v, want 3 rows", visited)
+	}
+
+	boom := errors.New("boom")
+	err = store.Iterate(ctx, nil, 2, func(f Fixture) error {
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("Iterate error = %v, want %v", err, boom)
+	}
+
+	first, err := store.SearchCursor(ctx, nil, "", 2)
+	if err != nil {
+		t.Fatalf("SearchCursor page 1: %v", err)
+	}
+	if len(first.Items) != 2 {
+		t.Fatalf("SearchCursor page 1 len(Items) = %d, want 2", len(first.Items))
+	}
+	if !first.HasMore || first.NextCursor == "" {
+		t.Fatal("SearchCursor page 1: want HasMore=true and a non-empty NextCursor")
+	}
+
+	second, err := store.SearchCursor(ctx, nil, first.NextCursor, 2)
+	if err != nil {
+		t.Fatalf("SearchCursor page 2: %v", err)
+	}
+	if len(second.Items) != 1 {
+		t.Fatalf("SearchCursor page 2 len(Items) = %d, want 1", len(second.Items))
+	}
+	if second.HasMore || second.NextCursor != "" {
+		t.Fatal("SearchCursor page 2: want HasMore=false and an empty NextCursor")
+	}
+
+	if _, err := store.SearchCursor(ctx, nil, "not-a-cursor", 2); err == nil {
+		t.Fatal("SearchCursor with a malformed cursor: want error, got nil")
+	}
+}
+
+func testTransactionRollback(t *testing.T, s Suite) {
+	ctx := context.Background()
+	store := s.NewStore(t)
+	tm := s.NewTxManager(t, store)
+
+	boom := errors.New("boom")
+	err := tm.Do(ctx, func(ctx context.Context) error {
+		if _, err := store.Save(ctx, Fixture{ID: "rolled-back", Name: "x"}); err != nil {
+			return err
+		}
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("tm.Do error = %v, want %v", err, boom)
+	}
+
+	if _, err := store.GetByID(ctx, "rolled-back"); err == nil {
+		t.Fatal("GetByID found a row saved by a rolled-back transaction")
+	}
+}
+
+// testTenantScopingOff asserts tenant.Off never scopes by tenant: rows from
+// every tenant are visible regardless of the ctx tenant, and Save doesn't
+// stamp one on.
+func testTenantScopingOff(t *testing.T, s Suite) {
+	store := s.NewTenantStore(t, tenant.Off)
+
+	ctxA := tenant.WithTenant(context.Background(), "tenant-a")
+	ctxB := tenant.WithTenant(context.Background(), "tenant-b")
+
+	if _, err := store.Save(ctxA, TenantFixture{ID: "row-1", TenantID: "tenant-a", Name: "x"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := store.GetByID(ctxB, "row-1")
+	if err != nil {
+		t.Fatalf("GetByID from a different tenant under tenant.Off: %v", err)
+	}
+	if got.ID != "row-1" {
+		t.Fatalf("GetByID returned %+v, want row-1", got)
+	}
+
+	if err := store.Delete(ctxB, "row-1"); err != nil {
+		t.Fatalf("Delete from a different tenant under tenant.Off: %v", err)
+	}
+	if _, err := store.GetByID(ctxA, "row-1"); err == nil {
+		t.Fatal("GetByID after Delete under tenant.Off: want not-found, got nil error")
+	}
+}
+
+// testTenantScopingStrict asserts tenant.Strict rejects calls with no ctx
+// tenant, and that GetByID/Update/Delete reject cross-tenant access rather
+// than trusting the caller-supplied row - the exact bypass chunk0-5's
+// Update fix closes.
+func testTenantScopingStrict(t *testing.T, s Suite) {
+	store := s.NewTenantStore(t, tenant.Strict)
+
+	ctxA := tenant.WithTenant(context.Background(), "tenant-a")
+	ctxB := tenant.WithTenant(context.Background(), "tenant-b")
+
+	if _, err := store.Save(context.Background(), TenantFixture{ID: "row-1", Name: "x"}); err == nil {
+		t.Fatal("Save with no ctx tenant under tenant.Strict: want error, got nil")
+	}
+
+	saved, err := store.Save(ctxA, TenantFixture{ID: "row-1", Name: "x"})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if saved.TenantID != "tenant-a" {
+		t.Fatalf("Save stamped TenantID=%q, want tenant-a", saved.TenantID)
+	}
+
+	if _, err := store.GetByID(ctxB, "row-1"); !errors.As(err, new(*errs.TenantMismatch)) {
+		t.Fatalf("GetByID from a different tenant error = %v, want *errs.TenantMismatch", err)
+	}
+
+	if _, err := store.Update(ctxB, TenantFixture{ID: "row-1", Name: "overwritten"}); !errors.As(err, new(*errs.TenantMismatch)) {
+		t.Fatalf("Update from a different tenant error = %v, want *errs.TenantMismatch", err)
+	}
+	if row, err := store.GetByID(ctxA, "row-1"); err != nil || row.Name != "x" {
+		t.Fatalf("GetByID after a rejected cross-tenant Update = %+v, %v; row must be untouched", row, err)
+	}
+
+	if err := store.Delete(ctxB, "row-1"); err != nil {
+		t.Fatalf("Delete from a different tenant: want a silent no-op, got error %v", err)
+	}
+	if _, err := store.GetByID(ctxA, "row-1"); err != nil {
+		t.Fatalf("GetByID after a cross-tenant Delete: row should survive, got %v", err)
+	}
+}
+
+// testTenantScopingOptional asserts tenant.Optional scopes when a ctx
+// tenant is present and falls back to unscoped behavior otherwise.
+func testTenantScopingOptional(t *testing.T, s Suite) {
+	store := s.NewTenantStore(t, tenant.Optional)
+
+	ctxA := tenant.WithTenant(context.Background(), "tenant-a")
+	ctxB := tenant.WithTenant(context.Background(), "tenant-b")
+
+	if _, err := store.Save(context.Background(), TenantFixture{ID: "row-1", Name: "x"}); err != nil {
+		t.Fatalf("Save with no ctx tenant under tenant.Optional: %v", err)
+	}
+
+	if _, err := store.GetByID(context.Background(), "row-1"); err != nil {
+		t.Fatalf("GetByID with no ctx tenant under tenant.Optional: %v", err)
+	}
+
+	if _, err := store.Save(ctxA, TenantFixture{ID: "row-2", Name: "y"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := store.GetByID(ctxB, "row-2"); !errors.As(err, new(*errs.TenantMismatch)) {
+		t.Fatalf("GetByID from a different tenant error = %v, want *errs.TenantMismatch", err)
+	}
+}
+
+func fmtID(i int) string {
+	return string(rune('a' + i))
+}
@@ -0,0 +1,64 @@
+package ent
+
+import (
+	"context"
+
+	"github.com/GabiHert/finance-tracker-e2e/pkg/event"
+)
+
+// Transactor is satisfied by a generated ent client that can open a *ent.Tx
+// and commit/rollback it; each schema's adapter wraps its own client to
+// provide this.
+type Transactor interface {
+	BeginTx(ctx context.Context) (context.Context, error)
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+// TxManager is the ent-backed persistence.TxManager, built around whatever
+// Transactor a schema's generated adapter provides. Returning the concrete
+// type here, rather than persistence.TxManager itself, keeps this package
+// independent of persistence - which imports ent back via Factory.NewStore.
+type TxManager struct {
+	transactor Transactor
+	publisher  event.Publisher
+}
+
+// NewTxManager builds the ent-backed TxManager.
+func NewTxManager(transactor Transactor, publisher event.Publisher) *TxManager {
+	return &TxManager{transactor: transactor, publisher: publisher}
+}
+
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	txCtx, err := m.transactor.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	txCtx = event.WithQueue(txCtx)
+
+	if err := fn(txCtx); err != nil {
+		rollbackErr := m.transactor.Rollback(txCtx)
+		event.Drain(txCtx)
+		if rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+
+	if err := m.transactor.Commit(txCtx); err != nil {
+		return err
+	}
+	m.publish(ctx, txCtx)
+	return nil
+}
+
+// publish flushes whatever events fn queued on txCtx. Only call it after a
+// successful commit - on rollback the write never happened, so those events
+// must be discarded, not delivered to downstream consumers.
+func (m *TxManager) publish(ctx context.Context, txCtx context.Context) {
+	events := event.Drain(txCtx)
+	if len(events) == 0 || m.publisher == nil {
+		return
+	}
+	_ = m.publisher.Publish(ctx, events...)
+}
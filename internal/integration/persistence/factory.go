@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/GabiHert/finance-tracker-e2e/internal/domain/entity"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/config"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/ent"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/gorm"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/memory"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/model"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/event"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/tenant"
+	gormmysql "gorm.io/driver/mysql"
+	gormpostgres "gorm.io/driver/postgres"
+	gormsqlite "gorm.io/driver/sqlite"
+	gormlib "gorm.io/gorm"
+)
+
+// Factory builds fully wired Store[E]/TxManager pairs for a given
+// config.Persistence block, so consumers can pick Postgres/MySQL/SQLite/
+// ent/in-memory without touching service code. It lazily opens and reuses a
+// single *gorm.DB across every gorm-backed NewStore call.
+//
+// The "ent" driver needs a per-entity generated EntClient/Transactor that
+// the Factory has no way to conjure on its own, so NewStore takes them as
+// parameters the same way it takes newModel for gorm; they're nil and
+// unused for every other driver.
+type Factory struct {
+	cfg config.Persistence
+	db  *gormlib.DB
+}
+
+// NewFactory builds a Factory for cfg. It does not open a connection until
+// the first gorm-backed NewStore call.
+func NewFactory(cfg config.Persistence) *Factory {
+	return &Factory{cfg: cfg}
+}
+
+func (f *Factory) gormDB() (*gormlib.DB, error) {
+	if f.db != nil {
+		return f.db, nil
+	}
+
+	var dialector gormlib.Dialector
+	switch f.cfg.Driver {
+	case "postgres":
+		dialector = gormpostgres.Open(f.cfg.DSN)
+	case "mysql":
+		dialector = gormmysql.Open(f.cfg.DSN)
+	case "sqlite":
+		dialector = gormsqlite.Open(f.cfg.DSN)
+	default:
+		return nil, fmt.Errorf("persistence: unsupported driver %q", f.cfg.Driver)
+	}
+
+	db, err := gormlib.Open(dialector, &gormlib.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	f.db = db
+	return db, nil
+}
+
+// NewStore wires a Store[E]/TxManager pair for the backend selected by
+// f's config.Persistence.Driver. newModel/notFoundCode/databaseCode are
+// only consulted by the gorm backend; memory only needs notFoundCode.
+// entClient/entTransactor are only consulted by the ent backend - pass the
+// schema's generated adapter into them the same way newModel wires a gorm
+// model, and "ent" becomes as selectable at boot as every other driver.
+// enforcement controls how the ctx tenant is applied for entities/models
+// that opt into tenant scoping; it's ignored for everything else.
+func NewStore[E entity.Domain, M model.Persisted[E]](
+	f *Factory,
+	newModel func(E) M,
+	notFoundCode string,
+	databaseCode string,
+	publisher event.Publisher,
+	enforcement tenant.Enforcement,
+	entClient ent.EntClient[E],
+	entTransactor ent.Transactor,
+) (Store[E], TxManager, error) {
+	switch f.cfg.Driver {
+	case "memory":
+		store := memory.New[E](notFoundCode, enforcement)
+		return store, memory.NewTxManager(store, publisher), nil
+	case "ent":
+		if entClient == nil || entTransactor == nil {
+			return nil, nil, fmt.Errorf("persistence: ent backend requires a non-nil entClient and entTransactor")
+		}
+		return ent.New[E](entClient), ent.NewTxManager(entTransactor, publisher), nil
+	default:
+		db, err := f.gormDB()
+		if err != nil {
+			return nil, nil, err
+		}
+		return gorm.NewRepository(db, newModel, notFoundCode, databaseCode, enforcement), gorm.NewTxManager(db, publisher), nil
+	}
+}
@@ -0,0 +1,76 @@
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	gormlib "gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// applyFilter reflects over filter's fields and adds a `column = ?` clause
+// for every pointer field that isn't nil, using its `filter:"column"` tag.
+// Zero-value (nil) fields are skipped so callers can pass a SearchFilter
+// with only the fields they care about set.
+func applyFilter(db *gormlib.DB, filter any) *gormlib.DB {
+	if filter == nil {
+		return db
+	}
+
+	v := reflect.ValueOf(filter)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return db
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return db
+	}
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+
+		column := t.Field(i).Tag.Get("filter")
+		if column == "" {
+			continue
+		}
+
+		db = db.Where(fmt.Sprintf("%s = ?", column), field.Elem().Interface())
+	}
+
+	return db
+}
+
+// invalidSortCode is used for every invalid-sort-field failure regardless
+// of entity, mirroring invalidCursorCode: an unrecognized SortSpec.Field is
+// a client bug, not a per-entity persistence error.
+const invalidSortCode = "PERSISTENCE-00401"
+
+// schemaCache is shared across every Repository[E, M] instance, the same
+// way gorm.DB shares schema caches internally; M's column set never changes
+// at runtime so there's no reason to re-derive it per Search call.
+var schemaCache sync.Map
+
+// sortColumns returns the set of database column names M's GORM schema
+// declares, resolved the same way gorm itself resolves them. Search uses it
+// to reject a SortSpec.Field that isn't an actual column instead of
+// interpolating arbitrary client input into ORDER BY.
+func sortColumns[M any](db *gormlib.DB) (map[string]bool, error) {
+	var zero M
+	s, err := schema.Parse(&zero, &schemaCache, db.NamingStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]bool, len(s.DBNames))
+	for _, name := range s.DBNames {
+		columns[name] = true
+	}
+	return columns, nil
+}
@@ -0,0 +1,223 @@
+package gorm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	applicationadapter "github.com/GabiHert/finance-tracker-e2e/internal/application/adapter"
+	"github.com/GabiHert/finance-tracker-e2e/internal/domain/entity"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/model"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/errs"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/logger"
+	gormlib "gorm.io/gorm"
+)
+
+// invalidCursorCode is used for every SearchCursor failure regardless of
+// entity: a malformed or stale cursor is a client bug, not a per-entity
+// persistence error, so it doesn't need one of the entity-specific codes
+// threaded through NewRepository.
+const invalidCursorCode = "PERSISTENCE-00400"
+
+// cursor is the payload a SearchCursor cursor string decodes to: the last
+// row of the previous page (for keyset resumption) plus a hash of the filter
+// that produced it, so resuming with a different filter is rejected instead
+// of silently skipping or repeating rows.
+type cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	FilterSum string    `json:"filter_sum"`
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, err
+	}
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return cursor{}, err
+	}
+	return c, nil
+}
+
+func filterSum(filter any) string {
+	raw, _ := json.Marshal(filter)
+	sum := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// hasTimestamp reports whether M implements model.Timestamped, i.e. whether
+// keyset ordering can use (created_at, id) instead of id alone.
+func hasTimestamp[M any]() bool {
+	var zero M
+	_, ok := any(&zero).(model.Timestamped)
+	return ok
+}
+
+// keysetOrder applies the ORDER BY keyset iteration relies on.
+func keysetOrder(query *gormlib.DB, timestamped bool) *gormlib.DB {
+	if timestamped {
+		return query.Order("created_at ASC").Order("id ASC")
+	}
+	return query.Order("id ASC")
+}
+
+// keysetAfter restricts query to rows strictly after the given keyset
+// position. A zero afterID means "from the start".
+func keysetAfter(query *gormlib.DB, timestamped bool, afterCreatedAt time.Time, afterID string) *gormlib.DB {
+	if afterID == "" {
+		return query
+	}
+	if timestamped {
+		return query.Where("created_at > ? OR (created_at = ? AND id > ?)", afterCreatedAt, afterCreatedAt, afterID)
+	}
+	return query.Where("id > ?", afterID)
+}
+
+// rowKeyset reads the keyset position of a row just loaded: created_at from
+// the model (when M implements model.Timestamped) and id from the domain
+// entity, since M itself carries no GetID - only E does, via entity.Domain.
+func rowKeyset[M any, E entity.Domain](modelObj M, entityObj E, timestamped bool) (time.Time, string) {
+	var createdAt time.Time
+	if timestamped {
+		if ts, ok := any(&modelObj).(model.Timestamped); ok {
+			createdAt = ts.GetCreatedAt()
+		}
+	}
+	return createdAt, entityObj.GetID()
+}
+
+// Iterate streams every row matching filter to fn in batches of batchSize,
+// ordered by keyset - (created_at, id) when M implements model.Timestamped,
+// id alone otherwise - instead of an OFFSET scan. It stops as soon as fn
+// returns an error or ctx is canceled, and never holds more than one batch
+// in memory at a time, which is what makes it safe for full-table exports
+// that Search's OFFSET pagination would choke on. When M is tenant-scoped,
+// results are additionally scoped to the ctx tenant, same as Search.
+func (r *Repository[E, M]) Iterate(ctx context.Context, filter any, batchSize int, fn func(E) error) error {
+	logger.Debug(ctx, "Started", filter)
+
+	tenantID, scoped, err := r.tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	timestamped := hasTimestamp[M]()
+	var afterCreatedAt time.Time
+	var afterID string
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		query := applyFilter(DBFromContext(ctx, r.db), filter)
+		if scoped {
+			query = query.Where("tenant_id = ?", tenantID)
+		}
+		query = keysetAfter(query, timestamped, afterCreatedAt, afterID)
+		query = keysetOrder(query, timestamped).Limit(batchSize)
+
+		var batch []M
+		if err := query.Find(&batch).Error; err != nil {
+			return errs.DatabaseError(err, r.databaseCode)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		var lastEntity E
+		for _, modelObj := range batch {
+			lastEntity = modelObj.ToEntity()
+			if err := fn(lastEntity); err != nil {
+				return err
+			}
+		}
+
+		afterCreatedAt, afterID = rowKeyset(batch[len(batch)-1], lastEntity, timestamped)
+
+		if len(batch) < batchSize {
+			return nil
+		}
+	}
+}
+
+// SearchCursor is Search's keyset-paginated counterpart: it applies filter
+// and returns at most limit rows ordered by the same keyset Iterate uses,
+// plus an opaque cursor encoding where to resume. Pass "" as cursor for the
+// first page. Resuming with a filter different from the one the cursor was
+// issued for returns an errs.InvalidCursor rather than silently mixing
+// result sets. When M is tenant-scoped, results are additionally scoped to
+// the ctx tenant, same as Search.
+func (r *Repository[E, M]) SearchCursor(
+	ctx context.Context,
+	filter any,
+	cursorStr string,
+	limit int,
+) (*applicationadapter.CursorPage[E], error) {
+	logger.Debug(ctx, "Started", filter)
+
+	tenantID, scoped, err := r.tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := filterSum(filter)
+	var afterCreatedAt time.Time
+	var afterID string
+	if cursorStr != "" {
+		c, err := decodeCursor(cursorStr)
+		if err != nil {
+			return nil, errs.InvalidCursorError("cursor is malformed", invalidCursorCode)
+		}
+		if c.FilterSum != sum {
+			return nil, errs.InvalidCursorError("cursor was issued for a different filter", invalidCursorCode)
+		}
+		afterCreatedAt, afterID = c.CreatedAt, c.ID
+	}
+
+	timestamped := hasTimestamp[M]()
+	query := applyFilter(DBFromContext(ctx, r.db), filter)
+	if scoped {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+	query = keysetAfter(query, timestamped, afterCreatedAt, afterID)
+	query = keysetOrder(query, timestamped).Limit(limit + 1)
+
+	var modelObjs []M
+	if err := query.Find(&modelObjs).Error; err != nil {
+		return nil, errs.DatabaseError(err, r.databaseCode)
+	}
+
+	hasMore := len(modelObjs) > limit
+	if hasMore {
+		modelObjs = modelObjs[:limit]
+	}
+
+	items := make([]E, 0, len(modelObjs))
+	for _, modelObj := range modelObjs {
+		items = append(items, modelObj.ToEntity())
+	}
+
+	var nextCursor string
+	if hasMore {
+		lastCreatedAt, lastID := rowKeyset(modelObjs[len(modelObjs)-1], items[len(items)-1], timestamped)
+		nextCursor = encodeCursor(cursor{CreatedAt: lastCreatedAt, ID: lastID, FilterSum: sum})
+	}
+
+	result := &applicationadapter.CursorPage[E]{
+		Items:      items,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}
+	logger.Debug(ctx, "Finished", len(items))
+	return result, nil
+}
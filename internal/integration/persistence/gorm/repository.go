@@ -0,0 +1,356 @@
+// Package gorm is the default persistence.Store[E] backend, implemented on
+// top of gorm.io/gorm.
+package gorm
+
+import (
+	"context"
+	"fmt"
+
+	applicationadapter "github.com/GabiHert/finance-tracker-e2e/internal/application/adapter"
+	"github.com/GabiHert/finance-tracker-e2e/internal/domain/entity"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/model"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/errs"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/logger"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/tenant"
+	gormlib "gorm.io/gorm"
+	"xorm.io/builder"
+)
+
+// Repository is the generic GORM-backed base every ENTITY_NAMERepository
+// composes instead of re-implementing the same lookup/save/error-mapping
+// boilerplate. E is the domain entity, M is its persisted representation.
+//
+// Entity-specific repositories should embed a *Repository[E, M] and add
+// only their own custom finders on top.
+type Repository[E entity.Domain, M model.Persisted[E]] struct {
+	db           *gormlib.DB
+	newModel     func(E) M
+	notFoundCode string
+	databaseCode string
+	enforcement  tenant.Enforcement
+}
+
+// NewRepository wires a generic Repository for entity E backed by model M.
+// notFoundCode/databaseCode are the stable error codes (e.g. "PREFIX-01404",
+// "PREFIX-02500") the entity-specific repository would otherwise hardcode.
+// enforcement controls how the ctx tenant (if any) is applied to M when M
+// implements model.TenantScoped; it has no effect otherwise.
+func NewRepository[E entity.Domain, M model.Persisted[E]](
+	db *gormlib.DB,
+	newModel func(E) M,
+	notFoundCode string,
+	databaseCode string,
+	enforcement tenant.Enforcement,
+) *Repository[E, M] {
+	return &Repository[E, M]{
+		db:           db,
+		newModel:     newModel,
+		notFoundCode: notFoundCode,
+		databaseCode: databaseCode,
+		enforcement:  enforcement,
+	}
+}
+
+// tenantScope resolves the tenant id to scope queries by, if M implements
+// model.TenantScoped and enforcement calls for it. ok is false when M isn't
+// tenant-scoped or enforcement is tenant.Off; err is set when enforcement is
+// tenant.Strict and ctx carries no tenant.
+func (r *Repository[E, M]) tenantScope(ctx context.Context) (id string, ok bool, err error) {
+	var modelObj M
+	if _, isScoped := any(&modelObj).(model.TenantScoped); !isScoped {
+		return "", false, nil
+	}
+	if r.enforcement == tenant.Off {
+		return "", false, nil
+	}
+
+	id, present := tenant.FromContext(ctx)
+	if !present {
+		if r.enforcement == tenant.Strict {
+			return "", false, errs.TenantMismatchError("no tenant on context", r.notFoundCode)
+		}
+		return "", false, nil
+	}
+	return id, true, nil
+}
+
+// Get returns the first row matching cond, mapped back to E. When M is
+// tenant-scoped, cond is ANDed with the ctx tenant.
+func (r *Repository[E, M]) Get(ctx context.Context, cond builder.Cond) (*E, bool, error) {
+	logger.Debug(ctx, "Started", cond)
+
+	tenantID, scoped, err := r.tenantScope(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if scoped {
+		cond = builder.And(cond, builder.Eq{"tenant_id": tenantID})
+	}
+
+	var modelObj M
+	sql, args, err := builder.ToSQL(cond)
+	if err != nil {
+		return nil, false, errs.DatabaseError(err, r.databaseCode)
+	}
+
+	err = DBFromContext(ctx, r.db).Where(sql, args...).First(&modelObj).Error
+	if err == gormlib.ErrRecordNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errs.DatabaseError(err, r.databaseCode)
+	}
+
+	result := modelObj.ToEntity()
+	logger.Debug(ctx, "Finished", result)
+	return &result, true, nil
+}
+
+// GetByID returns the entity with the given id, or an errs.NotFound. When M
+// is tenant-scoped, a row belonging to a different tenant than the one on
+// ctx yields an errs.TenantMismatch rather than being silently hidden.
+func (r *Repository[E, M]) GetByID(ctx context.Context, id string) (*E, error) {
+	logger.Debug(ctx, "Started", id)
+
+	tenantID, scoped, err := r.tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var modelObj M
+	err = DBFromContext(ctx, r.db).Where("id = ?", id).First(&modelObj).Error
+	if err == gormlib.ErrRecordNotFound {
+		return nil, errs.NotFoundError("record not found", r.notFoundCode)
+	}
+	if err != nil {
+		return nil, errs.DatabaseError(err, r.databaseCode)
+	}
+
+	if scoped {
+		if ts, ok := any(&modelObj).(model.TenantScoped); ok && ts.GetTenantID() != tenantID {
+			return nil, errs.TenantMismatchError("record belongs to a different tenant", r.notFoundCode)
+		}
+	}
+
+	result := modelObj.ToEntity()
+	logger.Debug(ctx, "Finished", result)
+	return &result, nil
+}
+
+// List returns the entities matching cond, paginated by page/limit (1-indexed
+// page). When M is tenant-scoped, cond is ANDed with the ctx tenant.
+func (r *Repository[E, M]) List(ctx context.Context, cond builder.Cond, page, limit int) ([]E, error) {
+	logger.Debug(ctx, "Started", cond)
+
+	tenantID, scoped, err := r.tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if scoped {
+		if cond != nil {
+			cond = builder.And(cond, builder.Eq{"tenant_id": tenantID})
+		} else {
+			cond = builder.Eq{"tenant_id": tenantID}
+		}
+	}
+
+	var modelObjs []M
+	query := DBFromContext(ctx, r.db)
+	if cond != nil {
+		sql, args, err := builder.ToSQL(cond)
+		if err != nil {
+			return nil, errs.DatabaseError(err, r.databaseCode)
+		}
+		query = query.Where(sql, args...)
+	}
+	if limit > 0 {
+		query = query.Limit(limit).Offset((page - 1) * limit)
+	}
+
+	if err := query.Find(&modelObjs).Error; err != nil {
+		return nil, errs.DatabaseError(err, r.databaseCode)
+	}
+
+	results := make([]E, 0, len(modelObjs))
+	for _, modelObj := range modelObjs {
+		results = append(results, modelObj.ToEntity())
+	}
+
+	logger.Debug(ctx, "Finished", len(results))
+	return results, nil
+}
+
+// Save inserts entityObj and returns it as it was persisted. When M is
+// tenant-scoped, the ctx tenant is stamped onto the row before it's created.
+func (r *Repository[E, M]) Save(ctx context.Context, entityObj E) (*E, error) {
+	logger.Debug(ctx, "Started", entityObj)
+
+	tenantID, scoped, err := r.tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modelObj := r.newModel(entityObj)
+	if scoped {
+		if ts, ok := any(&modelObj).(model.TenantScoped); ok {
+			ts.SetTenantID(tenantID)
+		}
+	}
+	if err := DBFromContext(ctx, r.db).Create(&modelObj).Error; err != nil {
+		return nil, errs.DatabaseError(err, r.databaseCode)
+	}
+
+	result := modelObj.ToEntity()
+	logger.Debug(ctx, "Finished", result)
+	return &result, nil
+}
+
+// Update persists the current state of entityObj. When M is tenant-scoped, a
+// row belonging to a different tenant than the one on ctx yields an
+// errs.TenantMismatch rather than being overwritten. That's enforced by the
+// UPDATE's own WHERE clause, not by inspecting entityObj's tenant field:
+// plain GORM Save ignores chained conditions and updates by primary key
+// alone, so a caller could otherwise blank out the tenant field and
+// overwrite another tenant's row. Model+Select("*")+Updates keeps Save's
+// full-row-replace semantics while still scoping the WHERE, the same way
+// Delete already scopes its DELETE.
+func (r *Repository[E, M]) Update(ctx context.Context, entityObj E) (*E, error) {
+	logger.Debug(ctx, "Started", entityObj)
+
+	tenantID, scoped, err := r.tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modelObj := r.newModel(entityObj)
+	db := DBFromContext(ctx, r.db)
+
+	if scoped {
+		if ts, ok := any(&modelObj).(model.TenantScoped); ok {
+			ts.SetTenantID(tenantID)
+			res := db.Model(&modelObj).Select("*").
+				Where("id = ? AND tenant_id = ?", entityObj.GetID(), tenantID).
+				Updates(&modelObj)
+			if res.Error != nil {
+				return nil, errs.DatabaseError(res.Error, r.databaseCode)
+			}
+			if res.RowsAffected == 0 {
+				return nil, errs.TenantMismatchError("record belongs to a different tenant", r.notFoundCode)
+			}
+
+			result := modelObj.ToEntity()
+			logger.Debug(ctx, "Finished", result)
+			return &result, nil
+		}
+	}
+
+	if err := db.Save(&modelObj).Error; err != nil {
+		return nil, errs.DatabaseError(err, r.databaseCode)
+	}
+
+	result := modelObj.ToEntity()
+	logger.Debug(ctx, "Finished", result)
+	return &result, nil
+}
+
+// Search applies a typed SearchFilter (any struct whose pointer fields carry
+// `filter:"column"` tags) plus pagination and sorting, and returns the
+// paginated envelope services hand back to their callers. nil-valued filter
+// fields are skipped, so services can pass a filter with only the fields
+// the caller actually set. When M is tenant-scoped, results are additionally
+// scoped to the ctx tenant.
+func (r *Repository[E, M]) Search(
+	ctx context.Context,
+	filter any,
+	pagination applicationadapter.Pagination,
+	sort []applicationadapter.SortSpec,
+) (*applicationadapter.PaginatedResult[E], error) {
+	logger.Debug(ctx, "Started", filter)
+
+	tenantID, scoped, err := r.tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := applyFilter(DBFromContext(ctx, r.db), filter)
+	if scoped {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+
+	var total int64
+	if err := query.Session(&gormlib.Session{}).Model(new(M)).Count(&total).Error; err != nil {
+		return nil, errs.DatabaseError(err, r.databaseCode)
+	}
+
+	if len(sort) > 0 {
+		columns, err := sortColumns[M](r.db)
+		if err != nil {
+			return nil, errs.DatabaseError(err, r.databaseCode)
+		}
+		for _, s := range sort {
+			if !columns[s.Field] {
+				return nil, errs.InvalidSortError(
+					fmt.Sprintf("%q is not a column this repository recognizes", s.Field),
+					invalidSortCode,
+				)
+			}
+			direction := "ASC"
+			if !s.Ascending {
+				direction = "DESC"
+			}
+			query = query.Order(fmt.Sprintf("%s %s", s.Field, direction))
+		}
+	}
+
+	page, perPage := pagination.Page, pagination.PerPage
+	if page == 0 {
+		page = 1
+	}
+	if perPage > 0 {
+		query = query.Limit(int(perPage)).Offset(int((page - 1) * perPage))
+	}
+
+	var modelObjs []M
+	if err := query.Find(&modelObjs).Error; err != nil {
+		return nil, errs.DatabaseError(err, r.databaseCode)
+	}
+
+	items := make([]E, 0, len(modelObjs))
+	for _, modelObj := range modelObjs {
+		items = append(items, modelObj.ToEntity())
+	}
+
+	result := &applicationadapter.PaginatedResult[E]{
+		Items:   items,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+		HasNext: int64((page-1)*perPage)+int64(len(items)) < total,
+	}
+	logger.Debug(ctx, "Finished", len(items))
+	return result, nil
+}
+
+// Delete removes the entity with the given id. When M is tenant-scoped, the
+// delete is additionally scoped to the ctx tenant so it silently no-ops
+// rather than removing a row belonging to a different tenant.
+func (r *Repository[E, M]) Delete(ctx context.Context, id string) error {
+	logger.Debug(ctx, "Started", id)
+
+	tenantID, scoped, err := r.tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	var modelObj M
+	query := DBFromContext(ctx, r.db).Where("id = ?", id)
+	if scoped {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+	if err := query.Delete(&modelObj).Error; err != nil {
+		return errs.DatabaseError(err, r.databaseCode)
+	}
+
+	logger.Debug(ctx, "Finished", id)
+	return nil
+}
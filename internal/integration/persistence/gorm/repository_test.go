@@ -0,0 +1,73 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/contract"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/gorm"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/tenant"
+	"gorm.io/driver/sqlite"
+	gormlib "gorm.io/gorm"
+)
+
+type fixtureModel struct {
+	ID   string `gorm:"primaryKey;column:id"`
+	Name string
+}
+
+func newFixtureModel(f contract.Fixture) fixtureModel {
+	return fixtureModel{ID: f.ID, Name: f.Name}
+}
+
+func (m fixtureModel) ToEntity() contract.Fixture {
+	return contract.Fixture{ID: m.ID, Name: m.Name}
+}
+
+// tenantFixtureModel backs contract.TenantFixture; it implements
+// model.TenantScoped so the conformance suite's tenant-scoping cases
+// actually exercise Repository's tenantScope path.
+type tenantFixtureModel struct {
+	ID       string `gorm:"primaryKey;column:id"`
+	TenantID string
+	Name     string
+}
+
+func newTenantFixtureModel(f contract.TenantFixture) tenantFixtureModel {
+	return tenantFixtureModel{ID: f.ID, TenantID: f.TenantID, Name: f.Name}
+}
+
+func (m tenantFixtureModel) ToEntity() contract.TenantFixture {
+	return contract.TenantFixture{ID: m.ID, TenantID: m.TenantID, Name: m.Name}
+}
+
+func (m tenantFixtureModel) GetTenantID() string    { return m.TenantID }
+func (m *tenantFixtureModel) SetTenantID(id string) { m.TenantID = id }
+
+func newTestDB(t *testing.T) *gormlib.DB {
+	db, err := gormlib.Open(sqlite.Open(":memory:"), &gormlib.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&fixtureModel{}, &tenantFixtureModel{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return db
+}
+
+func TestRepositoryConformance(t *testing.T) {
+	var db *gormlib.DB
+
+	contract.Run(t, contract.Suite{
+		NewStore: func(t *testing.T) persistence.Store[contract.Fixture] {
+			db = newTestDB(t)
+			return gorm.NewRepository(db, newFixtureModel, "FIXTURE-01404", "FIXTURE-02500", tenant.Off)
+		},
+		NewTxManager: func(t *testing.T, store persistence.Store[contract.Fixture]) persistence.TxManager {
+			return gorm.NewTxManager(db, nil)
+		},
+		NewTenantStore: func(t *testing.T, enforcement tenant.Enforcement) persistence.Store[contract.TenantFixture] {
+			return gorm.NewRepository(newTestDB(t), newTenantFixtureModel, "TENANT-01404", "TENANT-02500", enforcement)
+		},
+	})
+}
@@ -0,0 +1,72 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/GabiHert/finance-tracker-e2e/pkg/errs"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/event"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/logger"
+	gormlib "gorm.io/gorm"
+)
+
+// TxManager is the GORM-backed persistence.TxManager. It's returned as a
+// concrete type (rather than that interface) so this package never has to
+// import persistence, which would otherwise import it back via Factory.
+type TxManager struct {
+	db        *gormlib.DB
+	publisher event.Publisher
+}
+
+// NewTxManager builds the GORM-backed TxManager. publisher may be nil, in
+// which case queued events are dropped.
+func NewTxManager(db *gormlib.DB, publisher event.Publisher) *TxManager {
+	return &TxManager{db: db, publisher: publisher}
+}
+
+type txCtxKey struct{}
+
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := m.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return errs.DatabaseError(tx.Error, "TXMGR-02500")
+	}
+
+	txCtx := event.WithQueue(context.WithValue(ctx, txCtxKey{}, tx))
+
+	if err := fn(txCtx); err != nil {
+		logger.Debug(ctx, "Rolling back the transaction...", err)
+		tx.Rollback()
+		event.Drain(txCtx)
+		return err
+	}
+
+	logger.Debug(ctx, "Commit the transaction!", nil)
+	if err := tx.Commit().Error; err != nil {
+		return errs.DatabaseError(err, "TXMGR-02500")
+	}
+
+	m.publish(ctx, txCtx)
+	return nil
+}
+
+// publish flushes whatever events fn queued on txCtx. Only call it after a
+// successful commit - on rollback the write never happened, so those events
+// must be discarded, not delivered to downstream consumers.
+func (m *TxManager) publish(ctx context.Context, txCtx context.Context) {
+	events := event.Drain(txCtx)
+	if len(events) == 0 || m.publisher == nil {
+		return
+	}
+	if err := m.publisher.Publish(ctx, events...); err != nil {
+		logger.Error(ctx, "Failed to publish queued domain events", err)
+	}
+}
+
+// DBFromContext returns the *gorm.DB stashed on ctx by a TxManager, falling
+// back to fallback when ctx carries no transaction.
+func DBFromContext(ctx context.Context, fallback *gormlib.DB) *gormlib.DB {
+	if tx, ok := ctx.Value(txCtxKey{}).(*gormlib.DB); ok {
+		return tx
+	}
+	return fallback
+}
@@ -0,0 +1,24 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/contract"
+	"github.com/GabiHert/finance-tracker-e2e/internal/integration/persistence/memory"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/tenant"
+)
+
+func TestStoreConformance(t *testing.T) {
+	contract.Run(t, contract.Suite{
+		NewStore: func(t *testing.T) persistence.Store[contract.Fixture] {
+			return memory.New[contract.Fixture]("FIXTURE-01404", tenant.Off)
+		},
+		NewTxManager: func(t *testing.T, store persistence.Store[contract.Fixture]) persistence.TxManager {
+			return memory.NewTxManager(store.(*memory.Store[contract.Fixture]), nil)
+		},
+		NewTenantStore: func(t *testing.T, enforcement tenant.Enforcement) persistence.Store[contract.TenantFixture] {
+			return memory.New[contract.TenantFixture]("TENANT-01404", enforcement)
+		},
+	})
+}
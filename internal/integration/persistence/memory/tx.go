@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/GabiHert/finance-tracker-e2e/internal/domain/entity"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/event"
+	"github.com/GabiHert/finance-tracker-e2e/pkg/logger"
+)
+
+// TxManager gives the in-memory backend the same persistence.TxManager
+// surface as the real backends by snapshotting rows before fn runs and
+// restoring the snapshot on error - there's no real transaction to begin,
+// so the snapshot is the whole implementation. Returning this concrete type
+// instead of persistence.TxManager avoids an import cycle, since persistence
+// imports memory back via Factory.NewStore.
+type TxManager[E entity.Domain] struct {
+	store     *Store[E]
+	publisher event.Publisher
+}
+
+// NewTxManager builds the in-memory persistence.TxManager for store.
+func NewTxManager[E entity.Domain](store *Store[E], publisher event.Publisher) *TxManager[E] {
+	return &TxManager[E]{store: store, publisher: publisher}
+}
+
+func (m *TxManager[E]) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.store.mu.Lock()
+	snapshot := make(map[string]E, len(m.store.rows))
+	for id, row := range m.store.rows {
+		snapshot[id] = row
+	}
+	m.store.mu.Unlock()
+
+	txCtx := event.WithQueue(ctx)
+
+	if err := fn(txCtx); err != nil {
+		logger.Debug(ctx, "Rolling back the transaction...", err)
+		m.store.mu.Lock()
+		m.store.rows = snapshot
+		m.store.mu.Unlock()
+		event.Drain(txCtx)
+		return err
+	}
+
+	logger.Debug(ctx, "Commit the transaction!", nil)
+	m.publish(ctx, txCtx)
+	return nil
+}
+
+// publish flushes whatever events fn queued on txCtx. Only call it after a
+// successful commit - on rollback the write never happened, so those events
+// must be discarded, not delivered to downstream consumers.
+func (m *TxManager[E]) publish(ctx context.Context, txCtx context.Context) {
+	events := event.Drain(txCtx)
+	if len(events) == 0 || m.publisher == nil {
+		return
+	}
+	if err := m.publisher.Publish(ctx, events...); err != nil {
+		logger.Error(ctx, "Failed to publish queued domain events", err)
+	}
+}
@@ -0,0 +1,32 @@
+// Package model holds the GORM-mapped row types that back domain entities,
+// plus the conversion contract the generic repository relies on.
+package model
+
+import (
+	"time"
+
+	"github.com/GabiHert/finance-tracker-e2e/internal/domain/entity"
+)
+
+// Persisted is implemented by every GORM model. It lets the generic
+// repository turn a row it just loaded back into the domain entity E
+// without knowing the model's concrete shape.
+type Persisted[E entity.Domain] interface {
+	ToEntity() E
+}
+
+// TenantScoped is implemented by models whose table carries a tenant_id
+// column. The generic gorm repository type-asserts for it (on a *M) to
+// decide whether to scope queries and populate the column on Save.
+type TenantScoped interface {
+	GetTenantID() string
+	SetTenantID(id string)
+}
+
+// Timestamped is implemented by models whose table carries a created_at
+// column. Iterate and SearchCursor type-assert for it (on a *M) to order
+// keyset pagination by (created_at, id); models that don't implement it are
+// ordered by id alone.
+type Timestamped interface {
+	GetCreatedAt() time.Time
+}
@@ -0,0 +1,55 @@
+// Package persistence declares the backend-agnostic persistence contracts
+// (Store, TxManager) and the Factory that wires a concrete backend —
+// persistence/gorm, persistence/ent, or persistence/memory — behind them.
+// Entity-specific repositories in this package compose a Store[E] instead
+// of depending on any one backend directly.
+package persistence
+
+import (
+	"context"
+
+	applicationadapter "github.com/GabiHert/finance-tracker-e2e/internal/application/adapter"
+	"github.com/GabiHert/finance-tracker-e2e/internal/domain/entity"
+	"xorm.io/builder"
+)
+
+// Store is the behavioral contract every persistence backend must satisfy
+// for a given domain entity E. persistence/gorm, persistence/ent, and
+// persistence/memory all implement it, and the conformance suite in
+// persistence/contract asserts they do so identically.
+type Store[E entity.Domain] interface {
+	Get(ctx context.Context, cond builder.Cond) (*E, bool, error)
+	GetByID(ctx context.Context, id string) (*E, error)
+	List(ctx context.Context, cond builder.Cond, page, limit int) ([]E, error)
+	Search(
+		ctx context.Context,
+		filter any,
+		pagination applicationadapter.Pagination,
+		sort []applicationadapter.SortSpec,
+	) (*applicationadapter.PaginatedResult[E], error)
+	Save(ctx context.Context, entityObj E) (*E, error)
+	Update(ctx context.Context, entityObj E) (*E, error)
+	Delete(ctx context.Context, id string) error
+	// Iterate streams every row matching filter to fn in batches of
+	// batchSize, ordered by keyset rather than OFFSET, so callers can
+	// export or backfill a full table without materializing it in memory.
+	// It stops and returns fn's error immediately, or ctx.Err() if ctx is
+	// canceled between batches.
+	Iterate(ctx context.Context, filter any, batchSize int, fn func(E) error) error
+	// SearchCursor is Search's keyset-paginated counterpart: it returns at
+	// most limit rows plus an opaque cursor to resume from, instead of a
+	// Total/Page that would require an OFFSET scan. Pass "" as cursor for
+	// the first page.
+	SearchCursor(ctx context.Context, filter any, cursor string, limit int) (*applicationadapter.CursorPage[E], error)
+}
+
+// TxManager lets a service wrap several Store calls in a single unit of
+// work instead of each one committing independently. Not every backend
+// supports real atomicity (persistence/memory runs fn directly), but every
+// backend provides one so services can depend on the interface alone.
+type TxManager interface {
+	// Do runs fn inside a transaction, committing on a nil return and
+	// rolling back otherwise. Repositories must read the active
+	// transaction from the ctx fn receives to participate.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
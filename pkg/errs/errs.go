@@ -0,0 +1,89 @@
+// Package errs defines the sentinel error types returned across the
+// application and integration layers, each carrying a stable code so
+// clients and logs can key off it regardless of the underlying cause.
+package errs
+
+import "fmt"
+
+// NotFound is returned when a lookup found no matching record.
+type NotFound struct {
+	Code    string
+	Message string
+}
+
+func (e *NotFound) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+}
+
+// NotFoundError builds a *NotFound carrying the given message and code.
+func NotFoundError(message, code string) error {
+	return &NotFound{Code: code, Message: message}
+}
+
+// Database wraps an underlying persistence error so callers never leak
+// driver-specific types past the repository layer.
+type Database struct {
+	Code string
+	Err  error
+}
+
+func (e *Database) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Err)
+}
+
+func (e *Database) Unwrap() error {
+	return e.Err
+}
+
+// DatabaseError wraps err with the given code.
+func DatabaseError(err error, code string) error {
+	return &Database{Code: code, Err: err}
+}
+
+// TenantMismatch is returned when a lookup by id resolves to a record that
+// belongs to a different tenant than the one active on the context.
+type TenantMismatch struct {
+	Code    string
+	Message string
+}
+
+func (e *TenantMismatch) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+}
+
+// TenantMismatchError builds a *TenantMismatch carrying the given message and code.
+func TenantMismatchError(message, code string) error {
+	return &TenantMismatch{Code: code, Message: message}
+}
+
+// InvalidCursor is returned when a SearchCursor cursor is malformed, or was
+// issued for a different filter than the one it's being resumed with.
+type InvalidCursor struct {
+	Code    string
+	Message string
+}
+
+func (e *InvalidCursor) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+}
+
+// InvalidCursorError builds a *InvalidCursor carrying the given message and code.
+func InvalidCursorError(message, code string) error {
+	return &InvalidCursor{Code: code, Message: message}
+}
+
+// InvalidSort is returned when a Search's SortSpec names a field the
+// repository doesn't recognize as a column.
+type InvalidSort struct {
+	Code    string
+	Message string
+}
+
+func (e *InvalidSort) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+}
+
+// InvalidSortError builds a *InvalidSort carrying the given message and code.
+func InvalidSortError(message, code string) error {
+	return &InvalidSort{Code: code, Message: message}
+}
@@ -0,0 +1,45 @@
+// Package event provides a small context-scoped queue domain services use
+// to record events that should only become visible once the surrounding
+// transaction settles, plus the publisher port that drains them.
+package event
+
+import "context"
+
+// Event is a domain event queued during a unit of work.
+type Event interface {
+	Name() string
+}
+
+// Publisher dispatches events once a transaction has settled.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+type queueKey struct{}
+
+// WithQueue returns a context carrying an empty event queue. A
+// persistence.TxManager calls this once per unit of work.
+func WithQueue(ctx context.Context) context.Context {
+	queue := make([]Event, 0)
+	return context.WithValue(ctx, queueKey{}, &queue)
+}
+
+// Queue appends e to the queue carried by ctx. It is a no-op if ctx was not
+// produced by WithQueue, so callers outside a unit of work fail silently
+// rather than panicking.
+func Queue(ctx context.Context, e Event) {
+	if queue, ok := ctx.Value(queueKey{}).(*[]Event); ok {
+		*queue = append(*queue, e)
+	}
+}
+
+// Drain returns and clears every event queued on ctx so far.
+func Drain(ctx context.Context) []Event {
+	queue, ok := ctx.Value(queueKey{}).(*[]Event)
+	if !ok {
+		return nil
+	}
+	events := *queue
+	*queue = nil
+	return events
+}
@@ -0,0 +1,24 @@
+// Package logger provides the context-aware logging helpers used across
+// the application, integration, and domain layers so every call site logs
+// consistently without wiring a logger instance through every function.
+package logger
+
+import (
+	"context"
+	"log"
+)
+
+// Debug logs a debug-level message along with an associated payload.
+func Debug(ctx context.Context, message string, payload any) {
+	log.Printf("DEBUG %s %+v", message, payload)
+}
+
+// Info logs an info-level message along with an associated payload.
+func Info(ctx context.Context, message string, payload any) {
+	log.Printf("INFO %s %+v", message, payload)
+}
+
+// Error logs an error-level message along with an associated payload.
+func Error(ctx context.Context, message string, payload any) {
+	log.Printf("ERROR %s %+v", message, payload)
+}
@@ -0,0 +1,35 @@
+// Package tenant carries the current request's tenant id through a
+// context.Context so the persistence layer can scope reads/writes to it
+// without services plumbing it through every call explicitly.
+package tenant
+
+import "context"
+
+// Enforcement controls whether a repository requires, honors, or ignores
+// the tenant on ctx.
+type Enforcement int
+
+const (
+	// Strict requires a tenant on ctx; repositories reject calls made
+	// without one.
+	Strict Enforcement = iota
+	// Optional scopes by tenant when one is present on ctx and falls back
+	// to unscoped behavior otherwise.
+	Optional
+	// Off never scopes by tenant, regardless of ctx. Use this for
+	// system-level entities that have no tenant of their own.
+	Off
+)
+
+type ctxKey struct{}
+
+// WithTenant returns a context carrying id as the active tenant.
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the tenant id carried by ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ctxKey{}).(string)
+	return id, ok
+}